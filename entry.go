@@ -0,0 +1,94 @@
+package jmdict
+
+import "encoding/xml"
+
+// JMdict is the root of a parsed JMdict document.
+type JMdict struct {
+	XMLName xml.Name `xml:"JMdict"`
+	Entries []Entry  `xml:"entry"`
+}
+
+// Entry is a single JMdict dictionary entry: one or more kanji and/or
+// reading elements sharing one or more senses.
+type Entry struct {
+	Sequence        int              `xml:"ent_seq"`
+	KanjiElements   []KanjiElement   `xml:"k_ele"`
+	ReadingElements []ReadingElement `xml:"r_ele"`
+	Senses          []Sense          `xml:"sense"`
+}
+
+// KanjiElement ("k_ele") holds a kanji (or other non-kana) representation
+// of a word, along with any restrictions or priority markers on its use.
+type KanjiElement struct {
+	// Keb ("keb") is the kanji/reading-compound form of the word.
+	Keb string `xml:"keb"`
+	// Info ("ke_inf") holds entity codes qualifying the form, e.g. "iK".
+	Info []KanjiInfo `xml:"ke_inf"`
+	// Pri ("ke_pri") holds priority/frequency markers, e.g. "news1", "ichi1".
+	Pri []string `xml:"ke_pri"`
+}
+
+// ReadingElement ("r_ele") holds the reading (kana) of a word, along with
+// any restrictions or priority markers on its use.
+type ReadingElement struct {
+	// Reb ("reb") is the kana-only reading of the word.
+	Reb string `xml:"reb"`
+	// NoKanji ("re_nokanji") is present when the reading is not a true
+	// reading of any of the entry's kanji elements.
+	NoKanji *struct{} `xml:"re_nokanji"`
+	// Restrict ("re_restr") limits this reading to the listed kanji
+	// elements; empty means the reading applies to all of them.
+	Restrict []string `xml:"re_restr"`
+	// Info ("re_inf") holds entity codes qualifying the reading, e.g. "ok".
+	Info []ReadingInfo `xml:"re_inf"`
+	// Pri ("re_pri") holds priority/frequency markers, e.g. "news1", "ichi1".
+	Pri []string `xml:"re_pri"`
+}
+
+// Sense ("sense") is one translational/semantic sense of an entry.
+type Sense struct {
+	// StagKanji/StagReading ("stagk"/"stagr") restrict the sense to
+	// specific kanji or reading elements of the entry.
+	StagKanji   []string `xml:"stagk"`
+	StagReading []string `xml:"stagr"`
+	// PartsOfSpeech ("pos") holds part-of-speech entity codes.
+	PartsOfSpeech []POS `xml:"pos"`
+	// XRef ("xref") and Antonym ("ant") reference related entries,
+	// formatted as kanji/reading/sense-number joined by "\xb7".
+	XRef    []string `xml:"xref"`
+	Antonym []string `xml:"ant"`
+	// Field ("field") holds domain entity codes, e.g. "comp", "med".
+	Field []Field `xml:"field"`
+	// Misc ("misc") holds miscellaneous entity codes, e.g. "uk", "arch".
+	Misc []Misc `xml:"misc"`
+	// Info ("s_inf") is a free-text sense note.
+	Info []string `xml:"s_inf"`
+	// Source ("lsource") records loanword origins.
+	Source []LoanSource `xml:"lsource"`
+	// Dialect ("dial") holds dialect entity codes, e.g. "ksb".
+	Dialect []Dialect `xml:"dial"`
+	// Glosses ("gloss") holds the translated/explanatory text.
+	Glosses []Gloss `xml:"gloss"`
+}
+
+// LoanSource ("lsource") identifies the source word/phrase a loanword was
+// derived from.
+type LoanSource struct {
+	// Lang is the source language code (ISO 639-2/B), defaulting to "eng".
+	Lang string `xml:"lang,attr"`
+	// Type ("ls_type") is "full" (default) or "part" for a partial source.
+	Type string `xml:"ls_type,attr"`
+	// Wasei ("ls_wasei") is "y" if this is a Japanese-constructed word
+	// using foreign-derived morphemes (e.g. "wasei-eigo").
+	Wasei string `xml:"ls_wasei,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Gloss ("gloss") is a single translation or explanation of a sense.
+type Gloss struct {
+	// Lang is the gloss language code (ISO 639-2/B), defaulting to "eng".
+	Lang string `xml:"lang,attr"`
+	// Type ("g_type") is "lit", "fig", "expl", or "tm" when present.
+	Type  string `xml:"g_type,attr"`
+	Value string `xml:",chardata"`
+}
@@ -0,0 +1,336 @@
+// Package export converts parsed JMdict entries into a Yomichan/Yomitan v3
+// term-bank dictionary bundle: an index.json, sharded term_bank_N.json
+// files, and a tag_bank_N.json.
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wedgeV/jmdict"
+)
+
+// commonTag is the synthetic term tag applied to entries carrying a
+// common-word priority marker (news1, ichi1, spec1, gai1); unlike the
+// other tags it emits, it has no corresponding JMdict entity code.
+const commonTag = "P"
+
+// tagOrder overrides the default sort position Yomichan gives a tag in
+// tag_bank_N.json; tags not listed here sort at 0.
+var tagOrder = map[string]int{
+	"arch":    -5,
+	commonTag: -10,
+}
+
+// posRule maps a POS entity code to the Yomichan deinflection rule
+// identifier it implies. Godan verbs (v5*) are matched by prefix instead of
+// being listed individually.
+var posRule = map[string]string{
+	"v1": "v1", "v1-s": "v1",
+	"vk":     "vk",
+	"vs":     "vs",
+	"vs-s":   "vs",
+	"vs-i":   "vs",
+	"vz":     "vs",
+	"adj-i":  "adj-i",
+	"adj-ix": "adj-i",
+}
+
+// Options configures an Exporter's index.json and sharding.
+type Options struct {
+	Title       string
+	Revision    string
+	Author      string
+	URL         string
+	Description string
+	// EntriesPerBank caps how many rows go in each term_bank_N.json shard.
+	// Zero defaults to 10000.
+	EntriesPerBank int
+}
+
+// Exporter accumulates term rows from parsed entries and writes them out as
+// a Yomichan/Yomitan bundle on Close. Its Add method matches the
+// func(*jmdict.Entry) error signature jmdict.ParseStream expects, so an
+// Exporter can sit directly behind a streaming parse of the full
+// dictionary without holding a *jmdict.JMdict in memory.
+type Exporter struct {
+	dir  string
+	opts Options
+
+	terms [][]interface{}
+	tags  map[string]bool
+}
+
+// New creates an Exporter that writes its bundle into dir, which must
+// already exist.
+func New(dir string, opts Options) *Exporter {
+	if opts.EntriesPerBank == 0 {
+		opts.EntriesPerBank = 10000
+	}
+	return &Exporter{dir: dir, opts: opts, tags: map[string]bool{}}
+}
+
+// Add buffers the term-bank rows derived from e, one per applicable
+// kanji/reading pairing.
+func (x *Exporter) Add(e *jmdict.Entry) error {
+	x.terms = append(x.terms, x.rowsFor(e)...)
+	return nil
+}
+
+// Close writes index.json, the sharded term_bank_N.json files, and
+// tag_bank_1.json into the Exporter's directory.
+func (x *Exporter) Close() error {
+	if err := x.writeIndex(); err != nil {
+		return err
+	}
+	if err := x.writeTermBanks(); err != nil {
+		return err
+	}
+	return x.writeTagBank()
+}
+
+func (x *Exporter) rowsFor(e *jmdict.Entry) [][]interface{} {
+	rules := ruleIdentifiers(e)
+	if len(e.KanjiElements) == 0 {
+		rows := make([][]interface{}, 0, len(e.ReadingElements))
+		for _, r := range e.ReadingElements {
+			senses := sensesFor(e, "", r.Reb)
+			glossary := glossaryFor(senses)
+			if len(glossary) == 0 {
+				continue
+			}
+			rows = append(rows, x.row("", r.Reb, definitionTags(senses), rules, r.Pri, e.Sequence, glossary))
+		}
+		return rows
+	}
+	var rows [][]interface{}
+	for _, k := range e.KanjiElements {
+		for _, r := range e.ReadingElements {
+			if !readingApplies(r, k.Keb) {
+				continue
+			}
+			senses := sensesFor(e, k.Keb, r.Reb)
+			glossary := glossaryFor(senses)
+			if len(glossary) == 0 {
+				continue
+			}
+			pri := append(append([]string{}, k.Pri...), r.Pri...)
+			rows = append(rows, x.row(k.Keb, r.Reb, definitionTags(senses), rules, pri, e.Sequence, glossary))
+		}
+	}
+	return rows
+}
+
+// sensesFor returns the senses of e that apply to the keb/reb pairing a
+// row is being built for, honoring stagk/stagr restrictions. keb is ""
+// for reading-only entries (no kanji elements).
+func sensesFor(e *jmdict.Entry, keb, reb string) []jmdict.Sense {
+	var out []jmdict.Sense
+	for _, s := range e.Senses {
+		if len(s.StagKanji) > 0 && !containsString(s.StagKanji, keb) {
+			continue
+		}
+		if len(s.StagReading) > 0 && !containsString(s.StagReading, reb) {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func containsString(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func readingApplies(r jmdict.ReadingElement, keb string) bool {
+	if len(r.Restrict) == 0 {
+		return true
+	}
+	for _, restrict := range r.Restrict {
+		if restrict == keb {
+			return true
+		}
+	}
+	return false
+}
+
+// row builds one Yomichan term_bank row:
+// [expression, reading, definitionTags, ruleIdentifiers, score, glossary, sequence, termTags].
+// It also records which tags were used so writeTagBank only emits those.
+func (x *Exporter) row(expression, reading, defTags, rules string, pri []string, seq int, glossary []string) []interface{} {
+	for _, t := range strings.Fields(defTags) {
+		x.tags[t] = true
+	}
+	termTags := ""
+	if jmdict.HasCommonPriority(pri) {
+		termTags = commonTag
+		x.tags[commonTag] = true
+	}
+	return []interface{}{expression, reading, defTags, rules, scoreFor(pri), glossary, seq, termTags}
+}
+
+func definitionTags(senses []jmdict.Sense) string {
+	seen := map[string]bool{}
+	var tags []string
+	add := func(code string) {
+		if !seen[code] {
+			seen[code] = true
+			tags = append(tags, code)
+		}
+	}
+	for _, s := range senses {
+		for _, c := range s.PartsOfSpeech {
+			add(string(c))
+		}
+		for _, c := range s.Field {
+			add(string(c))
+		}
+		for _, c := range s.Misc {
+			add(string(c))
+		}
+		for _, c := range s.Dialect {
+			add(string(c))
+		}
+	}
+	return strings.Join(tags, " ")
+}
+
+func ruleIdentifiers(e *jmdict.Entry) string {
+	seen := map[string]bool{}
+	var rules []string
+	for _, s := range e.Senses {
+		for _, pos := range s.PartsOfSpeech {
+			code := string(pos)
+			rule, ok := posRule[code]
+			if !ok && strings.HasPrefix(code, "v5") {
+				rule, ok = "v5", true
+			}
+			if ok && !seen[rule] {
+				seen[rule] = true
+				rules = append(rules, rule)
+			}
+		}
+	}
+	return strings.Join(rules, " ")
+}
+
+func glossaryFor(senses []jmdict.Sense) []string {
+	var out []string
+	for _, s := range senses {
+		for _, g := range s.Glosses {
+			if g.Value != "" {
+				out = append(out, g.Value)
+			}
+		}
+	}
+	return out
+}
+
+// scoreFor derives a Yomichan sort score from ke_pri/re_pri frequency
+// markers: common-word entries sort above everything else.
+func scoreFor(pri []string) int {
+	if jmdict.HasCommonPriority(pri) {
+		return 5
+	}
+	return 0
+}
+
+func (x *Exporter) writeIndex() error {
+	idx := map[string]interface{}{
+		"title":     x.opts.Title,
+		"revision":  x.opts.Revision,
+		"sequenced": true,
+		"format":    3,
+	}
+	if x.opts.Author != "" {
+		idx["author"] = x.opts.Author
+	}
+	if x.opts.URL != "" {
+		idx["url"] = x.opts.URL
+	}
+	if x.opts.Description != "" {
+		idx["description"] = x.opts.Description
+	}
+	return writeJSON(filepath.Join(x.dir, "index.json"), idx)
+}
+
+func (x *Exporter) writeTermBanks() error {
+	if len(x.terms) == 0 {
+		return writeJSON(filepath.Join(x.dir, "term_bank_1.json"), []interface{}{})
+	}
+	for i := 0; i*x.opts.EntriesPerBank < len(x.terms); i++ {
+		start := i * x.opts.EntriesPerBank
+		end := start + x.opts.EntriesPerBank
+		if end > len(x.terms) {
+			end = len(x.terms)
+		}
+		name := filepath.Join(x.dir, "term_bank_"+strconv.Itoa(i+1)+".json")
+		if err := writeJSON(name, x.terms[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *Exporter) writeTagBank() error {
+	codes := make([]string, 0, len(x.tags))
+	for c := range x.tags {
+		codes = append(codes, c)
+	}
+	sort.Strings(codes)
+	rows := make([][]interface{}, 0, len(codes))
+	for _, c := range codes {
+		category, notes := tagMeta(c)
+		rows = append(rows, []interface{}{c, category, tagOrder[c], notes, 0})
+	}
+	return writeJSON(filepath.Join(x.dir, "tag_bank_1.json"), rows)
+}
+
+// yomichanCategory maps a jmdict.Category grouping to the Yomichan tag
+// category convention used for CSS styling in the tag bank.
+var yomichanCategory = map[jmdict.Category]string{
+	jmdict.CategoryPOS:         "partOfSpeech",
+	jmdict.CategoryDialect:     "dialect",
+	jmdict.CategoryField:       "field",
+	jmdict.CategoryMisc:        "misc",
+	jmdict.CategoryKanjiInfo:   "kanji-info",
+	jmdict.CategoryReadingInfo: "reading-info",
+}
+
+// categoryOverride gives specific codes a Yomichan tag category distinct
+// from their jmdict grouping, matching Yomichan's built-in category names.
+var categoryOverride = map[string]string{
+	"arch": "archaism",
+}
+
+func tagMeta(code string) (category, notes string) {
+	if code == commonTag {
+		return "popular", "common word"
+	}
+	info, ok := jmdict.LookupTag(code)
+	if !ok {
+		return "", ""
+	}
+	if c, ok := categoryOverride[code]; ok {
+		return c, info.Description
+	}
+	return yomichanCategory[info.Category], info.Description
+}
+
+func writeJSON(path string, v interface{}) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(v)
+}
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wedgeV/jmdict"
+)
+
+const ichidanEntryXML = `<?xml version="1.0" encoding="UTF-8"?>
+<JMdict>
+<entry>
+<ent_seq>1000</ent_seq>
+<k_ele><keb>食べる</keb></k_ele>
+<r_ele><reb>たべる</reb></r_ele>
+<sense><pos>&v1;</pos><gloss>to eat</gloss></sense>
+</entry>
+</JMdict>`
+
+// TestAddFromRealXMLProducesUsableCodes exercises the real xml.Decoder path
+// (rather than a hand-built jmdict.Entry), since the entity-expansion bug
+// that made every emitted code a multi-word description instead of the raw
+// DTD code only shows up once entities are actually decoded.
+func TestAddFromRealXMLProducesUsableCodes(t *testing.T) {
+	entries, err := jmdict.Parse(strings.NewReader(ichidanEntryXML))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	x := New(t.TempDir(), Options{})
+	if err := x.Add(&entries.Entries[0]); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if len(x.terms) != 1 {
+		t.Fatalf("got %d rows, want 1", len(x.terms))
+	}
+
+	row := x.terms[0]
+	if defTags := row[2]; defTags != "v1" {
+		t.Errorf("definitionTags = %q, want %q", defTags, "v1")
+	}
+	if rules := row[3]; rules != "v1" {
+		t.Errorf("ruleIdentifiers = %q, want %q", rules, "v1")
+	}
+}
+
+func TestRowsForHonorsStagr(t *testing.T) {
+	e := &jmdict.Entry{
+		Sequence: 1,
+		KanjiElements: []jmdict.KanjiElement{
+			{Keb: "一人"},
+		},
+		ReadingElements: []jmdict.ReadingElement{
+			{Reb: "ひとり"},
+			{Reb: "いちにん"},
+		},
+		Senses: []jmdict.Sense{
+			{
+				StagReading: []string{"ひとり"},
+				Glosses:     []jmdict.Gloss{{Value: "alone"}},
+			},
+			{
+				StagReading: []string{"いちにん"},
+				Glosses:     []jmdict.Gloss{{Value: "one person"}},
+			},
+		},
+	}
+
+	x := New(t.TempDir(), Options{})
+	rows := x.rowsFor(e)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+
+	glossFor := func(reading string) []string {
+		for _, row := range rows {
+			if row[1] == reading {
+				return row[5].([]string)
+			}
+		}
+		t.Fatalf("no row for reading %q", reading)
+		return nil
+	}
+
+	if g := glossFor("ひとり"); len(g) != 1 || g[0] != "alone" {
+		t.Errorf("ひとり glossary = %v, want [alone]", g)
+	}
+	if g := glossFor("いちにん"); len(g) != 1 || g[0] != "one person" {
+		t.Errorf("いちにん glossary = %v, want [one person]", g)
+	}
+}
+
+func TestTagMetaCategory(t *testing.T) {
+	category, notes := tagMeta("arch")
+	if category != "archaism" {
+		t.Errorf("tagMeta(%q) category = %q, want %q", "arch", category, "archaism")
+	}
+	if notes != "archaism" {
+		t.Errorf("tagMeta(%q) notes = %q, want %q", "arch", notes, "archaism")
+	}
+
+	if category, _ := tagMeta("v5k"); category != "partOfSpeech" {
+		t.Errorf("tagMeta(%q) category = %q, want %q", "v5k", category, "partOfSpeech")
+	}
+
+	if category, _ := tagMeta(commonTag); category != "popular" {
+		t.Errorf("tagMeta(%q) category = %q, want %q", commonTag, category, "popular")
+	}
+}
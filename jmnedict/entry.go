@@ -0,0 +1,92 @@
+// Package jmnedict implements a parser for JMnedict, the proper-name
+// companion to JMdict. JMnedict is available from the same EDRDG project
+// (http://www.edrdg.org/enamdict/enamdict_doc.html) and shares JMdict's
+// XML+entity structure, differing mainly in its name-type entities and in
+// carrying translations ("trans") instead of senses.
+package jmnedict
+
+import (
+	"encoding/xml"
+
+	"github.com/wedgeV/jmdict"
+)
+
+// entityDescription maps JMnedict's name_type entity codes to their
+// descriptions, the same role jmdict's entityDescription map plays for
+// JMdict.
+var entityDescription = map[string]string{
+	"c":  "company name",
+	"f":  "female given name or forename",
+	"g":  "given name or forename, gender not specified",
+	"h":  "full name of a particular person",
+	"m":  "male given name or forename",
+	"o":  "organization name",
+	"p":  "place name",
+	"pr": "product name",
+	"s":  "surname",
+	"st": "railway station",
+	"u":  "unclassified name",
+}
+
+// entity is fed to encoding/xml's Decoder.Entity so that an &code; entity
+// reference in the source JMnedict XML (e.g. &p;) decodes back to the raw
+// DTD code "p", not its human-readable expansion in entityDescription.
+var entity = map[string]string{
+	"c": "c", "f": "f", "g": "g", "h": "h", "m": "m", "o": "o",
+	"p": "p", "pr": "pr", "s": "s", "st": "st", "u": "u",
+}
+
+// NameType classifies a NameEntry's translation, e.g. NameTypeSurname or
+// NameTypePlace.
+type NameType string
+
+// The name-type entity codes defined by the JMnedict DTD.
+const (
+	NameTypeCompany      NameType = "c"
+	NameTypeFemaleGiven  NameType = "f"
+	NameTypeGiven        NameType = "g"
+	NameTypeFullName     NameType = "h"
+	NameTypeMaleGiven    NameType = "m"
+	NameTypeOrganization NameType = "o"
+	NameTypePlace        NameType = "p"
+	NameTypeProduct      NameType = "pr"
+	NameTypeSurname      NameType = "s"
+	NameTypeStation      NameType = "st"
+	NameTypeUnclassified NameType = "u"
+)
+
+// JMnedict is the root of a parsed JMnedict document.
+type JMnedict struct {
+	XMLName xml.Name    `xml:"JMnedict"`
+	Entries []NameEntry `xml:"entry"`
+}
+
+// NameEntry is a single JMnedict entry: one or more kanji and/or reading
+// elements sharing one or more translations. It reuses jmdict's
+// KanjiElement and ReadingElement since JMnedict's k_ele/r_ele elements
+// are identical to JMdict's.
+type NameEntry struct {
+	Sequence        int                     `xml:"ent_seq"`
+	KanjiElements   []jmdict.KanjiElement   `xml:"k_ele"`
+	ReadingElements []jmdict.ReadingElement `xml:"r_ele"`
+	Translations    []Translation           `xml:"trans"`
+}
+
+// Translation ("trans") is one translation of a NameEntry: its name
+// type(s), any cross-references, and the translated text itself.
+type Translation struct {
+	// NameType ("name_type") classifies the kind of name as its raw entity
+	// code, e.g. "s" (NameTypeSurname).
+	NameType []NameType `xml:"name_type"`
+	// XRef ("xref") references related entries.
+	XRef []string `xml:"xref"`
+	// Detail ("trans_det") holds the translated/romanized text.
+	Detail []TransDetail `xml:"trans_det"`
+}
+
+// TransDetail ("trans_det") is a single language-tagged translation of a
+// name.
+type TransDetail struct {
+	Lang  string `xml:"lang,attr"`
+	Value string `xml:",chardata"`
+}
@@ -0,0 +1,60 @@
+package jmnedict
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const sampleNamesXML = `<?xml version="1.0" encoding="UTF-8"?>
+<JMnedict>
+<entry>
+<ent_seq>5000001</ent_seq>
+<k_ele><keb>東京</keb></k_ele>
+<r_ele><reb>とうきょう</reb></r_ele>
+<trans><name_type>&p;</name_type><trans_det>Tokyo</trans_det></trans>
+</entry>
+<entry>
+<ent_seq>5000002</ent_seq>
+<r_ele><reb>たなか</reb></r_ele>
+<trans><name_type>&s;</name_type><trans_det>Tanaka</trans_det></trans>
+</entry>
+</JMnedict>`
+
+func TestParsePreservesRawNameTypeCodes(t *testing.T) {
+	result, err := Parse(strings.NewReader(sampleNamesXML))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(result.Entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(result.Entries))
+	}
+	nt := result.Entries[0].Translations[0].NameType
+	if len(nt) != 1 || nt[0] != NameTypePlace {
+		t.Errorf("NameType = %v, want [%v]", nt, NameTypePlace)
+	}
+}
+
+func TestParseStreamStopsOnErrStopParsing(t *testing.T) {
+	var seqs []int
+	err := ParseStream(strings.NewReader(sampleNamesXML), func(e *NameEntry) error {
+		seqs = append(seqs, e.Sequence)
+		return ErrStopParsing
+	})
+	if err != nil {
+		t.Fatalf("ParseStream: %v", err)
+	}
+	if len(seqs) != 1 || seqs[0] != 5000001 {
+		t.Errorf("seqs = %v, want [5000001]", seqs)
+	}
+}
+
+func TestParseStreamPropagatesCallbackError(t *testing.T) {
+	boom := errors.New("boom")
+	err := ParseStream(strings.NewReader(sampleNamesXML), func(e *NameEntry) error {
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("ParseStream err = %v, want %v", err, boom)
+	}
+}
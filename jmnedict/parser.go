@@ -0,0 +1,32 @@
+package jmnedict
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+
+	"github.com/wedgeV/jmdict"
+)
+
+// Parse parses the JMnedict file from r.
+func Parse(r io.Reader) (result *JMnedict, err error) {
+	d := xml.NewDecoder(r)
+	d.Entity = entity
+	d.Strict = false
+	if err := d.Decode(&result); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// ErrStopParsing can be returned by the callback passed to ParseStream to
+// halt iteration early without ParseStream reporting it as a failure.
+var ErrStopParsing = errors.New("jmnedict: stop parsing")
+
+// ParseStream reads a JMnedict document from r one <entry> element at a
+// time, decoding each individually and invoking fn, reusing jmdict's
+// streaming decoder plumbing so tools processing both files can bound
+// memory the same way for each.
+func ParseStream(r io.Reader, fn func(*NameEntry) error) error {
+	return jmdict.StreamDecode(r, entity, "entry", ErrStopParsing, fn)
+}
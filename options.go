@@ -0,0 +1,162 @@
+package jmdict
+
+import "io"
+
+// CommonPriority lists the ke_pri/re_pri markers JMdict uses to flag a
+// common word, mirroring the "P" filter from early Yomichan compile
+// scripts. Exported so other packages deriving a "common word" notion,
+// such as export's term-bank scoring, don't need their own copy.
+var CommonPriority = map[string]bool{
+	"news1": true, "ichi1": true, "spec1": true, "gai1": true,
+}
+
+// HasCommonPriority reports whether pri, a KanjiElement's or
+// ReadingElement's Pri list, carries one of CommonPriority's markers.
+func HasCommonPriority(pri []string) bool {
+	return intersects(pri, CommonPriority)
+}
+
+// ParseOptions configures ParseWith, letting callers filter entries and
+// senses during decoding instead of after, so they don't pay the memory
+// cost of data they don't want.
+type ParseOptions struct {
+	// Languages restricts <gloss> elements to these xml:lang codes (e.g.
+	// "eng", "ger"). Glosses without an xml:lang attribute are treated as
+	// "eng", the JMdict DTD default. Empty keeps every language.
+	Languages []string
+
+	// DropEmptySenses removes senses left with no glosses after language
+	// filtering, and drops entries left with no senses at all.
+	DropEmptySenses bool
+
+	// KeepEntities, if non-empty, restricts senses to those whose pos,
+	// field, misc, or dial codes intersect this set.
+	KeepEntities []string
+
+	// ExcludeMisc drops senses whose misc codes intersect this set, e.g.
+	// []string{string(MiscArchaism), string(MiscObsolete)} to suppress
+	// archaic/obsolete senses.
+	ExcludeMisc []string
+
+	// CommonOnly restricts to entries carrying a common-word ke_pri/re_pri
+	// marker (news1, ichi1, spec1, or gai1).
+	CommonOnly bool
+}
+
+// ParseWith reads a JMdict document from r the same way ParseStream does,
+// but applies opts to each entry before it is kept, so filtered-out
+// languages, senses, and entries never end up in the returned *JMdict.
+func ParseWith(r io.Reader, opts ParseOptions) (*JMdict, error) {
+	result := &JMdict{}
+	err := ParseStreamWith(r, opts, func(e *Entry) error {
+		result.Entries = append(result.Entries, *e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ParseStreamWith reads a JMdict document from r the same way ParseStream
+// does, applying opts to each entry before invoking fn, so a caller can
+// combine opts' gloss/sense filtering with ParseStream's bounded memory,
+// e.g. feeding a single-language export.Exporter without ever holding the
+// rest of the dictionary's languages, or the dictionary itself, in memory.
+//
+// fn may return ErrStopParsing to stop iteration early, the same as
+// ParseStream.
+func ParseStreamWith(r io.Reader, opts ParseOptions, fn func(*Entry) error) error {
+	langs := toSet(opts.Languages)
+	keep := toSet(opts.KeepEntities)
+	exclude := toSet(opts.ExcludeMisc)
+
+	return ParseStream(r, func(e *Entry) error {
+		if opts.CommonOnly && !isCommon(e) {
+			return nil
+		}
+		filterSenses(e, langs, keep, exclude, opts.DropEmptySenses)
+		if opts.DropEmptySenses && len(e.Senses) == 0 {
+			return nil
+		}
+		return fn(e)
+	})
+}
+
+func isCommon(e *Entry) bool {
+	for _, k := range e.KanjiElements {
+		if HasCommonPriority(k.Pri) {
+			return true
+		}
+	}
+	for _, rd := range e.ReadingElements {
+		if HasCommonPriority(rd.Pri) {
+			return true
+		}
+	}
+	return false
+}
+
+func filterSenses(e *Entry, langs, keep, exclude map[string]bool, dropEmpty bool) {
+	var senses []Sense
+	for _, s := range e.Senses {
+		if len(exclude) > 0 && intersects(s.Misc, exclude) {
+			continue
+		}
+		if len(keep) > 0 && !senseIntersects(s, keep) {
+			continue
+		}
+		if len(langs) > 0 {
+			s.Glosses = filterGlosses(s.Glosses, langs)
+		}
+		if dropEmpty && len(s.Glosses) == 0 {
+			continue
+		}
+		senses = append(senses, s)
+	}
+	e.Senses = senses
+}
+
+func filterGlosses(glosses []Gloss, langs map[string]bool) []Gloss {
+	var out []Gloss
+	for _, g := range glosses {
+		lang := g.Lang
+		if lang == "" {
+			lang = "eng"
+		}
+		if langs[lang] {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+func senseIntersects(s Sense, set map[string]bool) bool {
+	return intersects(s.PartsOfSpeech, set) ||
+		intersects(s.Field, set) ||
+		intersects(s.Misc, set) ||
+		intersects(s.Dialect, set)
+}
+
+// intersects reports whether any of codes is a key of set. It is generic
+// over the entity code types (POS, Field, Misc, Dialect, ...), all of
+// which are defined as distinct string types, as well as plain string.
+func intersects[T ~string](codes []T, set map[string]bool) bool {
+	for _, c := range codes {
+		if set[string(c)] {
+			return true
+		}
+	}
+	return false
+}
+
+func toSet(list []string) map[string]bool {
+	if len(list) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(list))
+	for _, v := range list {
+		set[v] = true
+	}
+	return set
+}
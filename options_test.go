@@ -0,0 +1,56 @@
+package jmdict
+
+import (
+	"strings"
+	"testing"
+)
+
+const twoSenseEntryXML = `<?xml version="1.0" encoding="UTF-8"?>
+<JMdict>
+<entry>
+<ent_seq>1000</ent_seq>
+<k_ele><keb>逢う</keb></k_ele>
+<r_ele><reb>あう</reb></r_ele>
+<sense><pos>&v5u;</pos><gloss>to meet</gloss></sense>
+<sense><pos>&v5u;</pos><misc>&arch;</misc><gloss>to have an unfortunate encounter</gloss></sense>
+</entry>
+</JMdict>`
+
+func TestParseWithExcludeMisc(t *testing.T) {
+	result, err := ParseWith(strings.NewReader(twoSenseEntryXML), ParseOptions{
+		ExcludeMisc: []string{"arch"},
+	})
+	if err != nil {
+		t.Fatalf("ParseWith: %v", err)
+	}
+	if len(result.Entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(result.Entries))
+	}
+	senses := result.Entries[0].Senses
+	if len(senses) != 1 {
+		t.Fatalf("got %d senses, want 1 (archaic sense should be excluded)", len(senses))
+	}
+	if senses[0].Glosses[0].Value != "to meet" {
+		t.Errorf("surviving sense gloss = %q, want %q", senses[0].Glosses[0].Value, "to meet")
+	}
+}
+
+func TestParseStreamWithCallsFnPerFilteredEntry(t *testing.T) {
+	var seen []string
+	err := ParseStreamWith(strings.NewReader(twoSenseEntryXML), ParseOptions{
+		ExcludeMisc: []string{"arch"},
+	}, func(e *Entry) error {
+		for _, s := range e.Senses {
+			for _, g := range s.Glosses {
+				seen = append(seen, g.Value)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ParseStreamWith: %v", err)
+	}
+	if len(seen) != 1 || seen[0] != "to meet" {
+		t.Errorf("seen = %v, want [to meet]", seen)
+	}
+}
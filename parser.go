@@ -4,10 +4,14 @@ package jmdict
 
 import (
 	"encoding/xml"
+	"errors"
 	"io"
 )
 
-var entity = map[string]string{
+// entityDescription maps each JMdict DTD entity code to its human-readable
+// expansion, e.g. "v5k" -> "godan verb", "arch" -> "archaism". LookupTag and
+// tag metadata use this; it is never handed to xml.Decoder (see entity).
+var entityDescription = map[string]string{
 	"MA":        "martial arts term",
 	"X":         "rude or x-rated term (not displayed in educational software)",
 	"abbr":      "abbreviation",
@@ -183,6 +187,20 @@ var entity = map[string]string{
 	"quote":     "\"",
 }
 
+// entity is fed to encoding/xml's Decoder.Entity so that an &code; entity
+// reference in the source JMdict XML (e.g. &v5k;) decodes back to the raw
+// DTD code "v5k", not its human-readable expansion in entityDescription.
+// Callers that want the expansion look it up via LookupTag instead.
+var entity = identityEntities(entityDescription)
+
+func identityEntities(desc map[string]string) map[string]string {
+	m := make(map[string]string, len(desc))
+	for code := range desc {
+		m[code] = code
+	}
+	return m
+}
+
 // Parse parses the JMdict file from r.
 func Parse(r io.Reader) (result *JMdict, err error) {
 	d := xml.NewDecoder(r)
@@ -193,3 +211,57 @@ func Parse(r io.Reader) (result *JMdict, err error) {
 	}
 	return
 }
+
+// ErrStopParsing can be returned by the callback passed to ParseStream to
+// halt iteration early without ParseStream reporting it as a failure.
+var ErrStopParsing = errors.New("jmdict: stop parsing")
+
+// ParseStream reads a JMdict document from r one <entry> element at a time,
+// decoding each individually and invoking fn, rather than building the whole
+// *JMdict in memory the way Parse does. This keeps memory bounded when
+// processing the full dictionary, e.g. to build an external index or
+// database.
+//
+// fn may return ErrStopParsing to stop iteration early; ParseStream then
+// returns nil. Any other error from fn aborts and is returned as-is.
+func ParseStream(r io.Reader, fn func(*Entry) error) error {
+	return StreamDecode(r, entity, "entry", ErrStopParsing, fn)
+}
+
+// StreamDecode is the token-loop behind ParseStream: it decodes r one
+// element named elemName at a time into a fresh T and invokes fn, never
+// holding more than one element in memory at once. It is exported so
+// other EDRDG-format parsers, such as jmnedict.ParseStream, can reuse the
+// same streaming plumbing against their own entity map and element type.
+//
+// fn may return stopErr to halt iteration early without StreamDecode
+// reporting it as a failure. Any other error from fn aborts and is
+// returned as-is.
+func StreamDecode[T any](r io.Reader, entityMap map[string]string, elemName string, stopErr error, fn func(*T) error) error {
+	d := xml.NewDecoder(r)
+	d.Entity = entityMap
+	d.Strict = false
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != elemName {
+			continue
+		}
+		var e T
+		if err := d.DecodeElement(&e, &start); err != nil {
+			return err
+		}
+		if err := fn(&e); err != nil {
+			if err == stopErr {
+				return nil
+			}
+			return err
+		}
+	}
+}
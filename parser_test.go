@@ -0,0 +1,63 @@
+package jmdict
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const sampleEntriesXML = `<?xml version="1.0" encoding="UTF-8"?>
+<JMdict>
+<entry>
+<ent_seq>1000</ent_seq>
+<k_ele><keb>食べる</keb></k_ele>
+<r_ele><reb>たべる</reb></r_ele>
+<sense><pos>&v1;</pos><misc>&arch;</misc><gloss>to eat</gloss></sense>
+</entry>
+<entry>
+<ent_seq>2000</ent_seq>
+<r_ele><reb>にほん</reb></r_ele>
+<sense><pos>&n;</pos><gloss>Japan</gloss></sense>
+</entry>
+</JMdict>`
+
+func TestParsePreservesRawEntityCodes(t *testing.T) {
+	result, err := Parse(strings.NewReader(sampleEntriesXML))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(result.Entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(result.Entries))
+	}
+	s := result.Entries[0].Senses[0]
+	if got, want := s.PartsOfSpeech, []POS{"v1"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("PartsOfSpeech = %v, want %v", got, want)
+	}
+	if got, want := s.Misc, []Misc{"arch"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("Misc = %v, want %v", got, want)
+	}
+}
+
+func TestParseStreamStopsOnErrStopParsing(t *testing.T) {
+	var seqs []int
+	err := ParseStream(strings.NewReader(sampleEntriesXML), func(e *Entry) error {
+		seqs = append(seqs, e.Sequence)
+		return ErrStopParsing
+	})
+	if err != nil {
+		t.Fatalf("ParseStream: %v", err)
+	}
+	if len(seqs) != 1 || seqs[0] != 1000 {
+		t.Errorf("seqs = %v, want [1000] (stream should stop after the first entry)", seqs)
+	}
+}
+
+func TestParseStreamPropagatesCallbackError(t *testing.T) {
+	boom := errors.New("boom")
+	err := ParseStream(strings.NewReader(sampleEntriesXML), func(e *Entry) error {
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("ParseStream err = %v, want %v", err, boom)
+	}
+}
@@ -0,0 +1,85 @@
+package sqlstore
+
+// schema creates the normalized tables Import populates, plus FTS5 virtual
+// tables over kanji, reading, and gloss text for token and prefix search.
+// The FTS5 tables use the default unicode61 tokenizer, so a run of kanji or
+// kana is one token and a substring inside a token does not match.
+// Statements are all "IF NOT EXISTS" so the schema step can run repeatedly
+// against the same database; importing an entry whose sequence is already
+// stored fails on the entries.sequence UNIQUE constraint.
+const schema = `
+CREATE TABLE IF NOT EXISTS entries (
+	id       INTEGER PRIMARY KEY,
+	sequence INTEGER NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS kanji_elements (
+	id       INTEGER PRIMARY KEY,
+	entry_id INTEGER NOT NULL REFERENCES entries(id),
+	keb      TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS reading_elements (
+	id       INTEGER PRIMARY KEY,
+	entry_id INTEGER NOT NULL REFERENCES entries(id),
+	reb      TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS senses (
+	id       INTEGER PRIMARY KEY,
+	entry_id INTEGER NOT NULL REFERENCES entries(id),
+	position INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS glosses (
+	id       INTEGER PRIMARY KEY,
+	sense_id INTEGER NOT NULL REFERENCES senses(id),
+	lang     TEXT NOT NULL,
+	value    TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS sense_pos (
+	sense_id INTEGER NOT NULL REFERENCES senses(id),
+	code     TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS sense_field (
+	sense_id INTEGER NOT NULL REFERENCES senses(id),
+	code     TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS sense_dialect (
+	sense_id INTEGER NOT NULL REFERENCES senses(id),
+	code     TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS sense_misc (
+	sense_id INTEGER NOT NULL REFERENCES senses(id),
+	code     TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS cross_refs (
+	sense_id INTEGER NOT NULL REFERENCES senses(id),
+	target   TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS antonyms (
+	sense_id INTEGER NOT NULL REFERENCES senses(id),
+	target   TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS loanwords (
+	sense_id INTEGER NOT NULL REFERENCES senses(id),
+	lang     TEXT NOT NULL,
+	ls_type  TEXT NOT NULL,
+	wasei    TEXT NOT NULL,
+	value    TEXT NOT NULL
+);
+
+CREATE VIRTUAL TABLE IF NOT EXISTS kanji_fts
+	USING fts5(keb, content='kanji_elements', content_rowid='id');
+CREATE VIRTUAL TABLE IF NOT EXISTS reading_fts
+	USING fts5(reb, content='reading_elements', content_rowid='id');
+CREATE VIRTUAL TABLE IF NOT EXISTS gloss_fts
+	USING fts5(value, content='glosses', content_rowid='id');
+`
@@ -0,0 +1,277 @@
+// Package sqlstore streams JMdict entries into a normalized SQLite schema,
+// following the shape of the yokome project's dictionary-to-RDBMS importer,
+// so callers get a persistent, queryable store without holding the full
+// dictionary in memory.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"io"
+
+	"github.com/wedgeV/jmdict"
+)
+
+// defaultBatchSize is used when ImportOptions.BatchSize is zero.
+const defaultBatchSize = 1000
+
+// ImportOptions configures Import.
+type ImportOptions struct {
+	// BatchSize caps how many entries are committed per transaction.
+	// Zero defaults to 1000.
+	BatchSize int
+}
+
+// Import streams entries from r into db, creating the schema (see
+// schema.go) if it doesn't already exist. db must be open against a SQLite
+// driver that supports FTS5; the caller owns registering and opening it.
+// Entries are committed in batches of opts.BatchSize for throughput.
+func Import(ctx context.Context, db *sql.DB, r io.Reader, opts ImportOptions) error {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		return err
+	}
+
+	imp := &importer{ctx: ctx, db: db, batchSize: opts.BatchSize}
+	if err := jmdict.ParseStream(r, imp.add); err != nil {
+		imp.abort()
+		return err
+	}
+	return imp.flush()
+}
+
+// importer batches entries from ParseStream's callback into transactions.
+type importer struct {
+	ctx       context.Context
+	db        *sql.DB
+	batchSize int
+
+	tx      *sql.Tx
+	stmts   *stmts
+	pending int
+}
+
+func (i *importer) add(e *jmdict.Entry) error {
+	if i.tx == nil {
+		if err := i.begin(); err != nil {
+			return err
+		}
+	}
+	if err := i.insertEntry(e); err != nil {
+		i.abort()
+		return err
+	}
+	i.pending++
+	if i.pending >= i.batchSize {
+		return i.flush()
+	}
+	return nil
+}
+
+func (i *importer) begin() error {
+	tx, err := i.db.BeginTx(i.ctx, nil)
+	if err != nil {
+		return err
+	}
+	st, err := prepareStmts(i.ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	i.tx, i.stmts = tx, st
+	return nil
+}
+
+func (i *importer) flush() error {
+	if i.tx == nil {
+		return nil
+	}
+	i.stmts.close()
+	err := i.tx.Commit()
+	i.tx, i.stmts, i.pending = nil, nil, 0
+	return err
+}
+
+func (i *importer) abort() {
+	if i.tx == nil {
+		return
+	}
+	i.stmts.close()
+	i.tx.Rollback()
+	i.tx, i.stmts, i.pending = nil, nil, 0
+}
+
+func (i *importer) insertEntry(e *jmdict.Entry) error {
+	res, err := i.stmts.insertEntry.ExecContext(i.ctx, e.Sequence)
+	if err != nil {
+		return err
+	}
+	entryID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	for _, k := range e.KanjiElements {
+		res, err := i.stmts.insertKanji.ExecContext(i.ctx, entryID, k.Keb)
+		if err != nil {
+			return err
+		}
+		kanjiID, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		if _, err := i.stmts.insertKanjiFTS.ExecContext(i.ctx, kanjiID, k.Keb); err != nil {
+			return err
+		}
+	}
+	for _, rd := range e.ReadingElements {
+		res, err := i.stmts.insertReading.ExecContext(i.ctx, entryID, rd.Reb)
+		if err != nil {
+			return err
+		}
+		readingID, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		if _, err := i.stmts.insertReadingFTS.ExecContext(i.ctx, readingID, rd.Reb); err != nil {
+			return err
+		}
+	}
+	for pos, s := range e.Senses {
+		if err := i.insertSense(entryID, pos, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *importer) insertSense(entryID int64, position int, s jmdict.Sense) error {
+	res, err := i.stmts.insertSense.ExecContext(i.ctx, entryID, position)
+	if err != nil {
+		return err
+	}
+	senseID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	for _, g := range s.Glosses {
+		res, err := i.stmts.insertGloss.ExecContext(i.ctx, senseID, g.Lang, g.Value)
+		if err != nil {
+			return err
+		}
+		glossID, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		if _, err := i.stmts.insertGlossFTS.ExecContext(i.ctx, glossID, g.Value); err != nil {
+			return err
+		}
+	}
+	for _, code := range s.PartsOfSpeech {
+		if _, err := i.stmts.insertPOS.ExecContext(i.ctx, senseID, string(code)); err != nil {
+			return err
+		}
+	}
+	for _, code := range s.Field {
+		if _, err := i.stmts.insertField.ExecContext(i.ctx, senseID, string(code)); err != nil {
+			return err
+		}
+	}
+	for _, code := range s.Dialect {
+		if _, err := i.stmts.insertDialect.ExecContext(i.ctx, senseID, string(code)); err != nil {
+			return err
+		}
+	}
+	for _, code := range s.Misc {
+		if _, err := i.stmts.insertMisc.ExecContext(i.ctx, senseID, string(code)); err != nil {
+			return err
+		}
+	}
+	for _, xref := range s.XRef {
+		if _, err := i.stmts.insertXRef.ExecContext(i.ctx, senseID, xref); err != nil {
+			return err
+		}
+	}
+	for _, ant := range s.Antonym {
+		if _, err := i.stmts.insertAntonym.ExecContext(i.ctx, senseID, ant); err != nil {
+			return err
+		}
+	}
+	for _, ls := range s.Source {
+		if _, err := i.stmts.insertLoanword.ExecContext(i.ctx, senseID, ls.Lang, ls.Type, ls.Wasei, ls.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stmts holds the prepared statements used to populate one transaction's
+// worth of entries.
+type stmts struct {
+	insertEntry      *sql.Stmt
+	insertKanji      *sql.Stmt
+	insertKanjiFTS   *sql.Stmt
+	insertReading    *sql.Stmt
+	insertReadingFTS *sql.Stmt
+	insertSense      *sql.Stmt
+	insertGloss      *sql.Stmt
+	insertGlossFTS   *sql.Stmt
+	insertPOS        *sql.Stmt
+	insertField      *sql.Stmt
+	insertDialect    *sql.Stmt
+	insertMisc       *sql.Stmt
+	insertXRef       *sql.Stmt
+	insertAntonym    *sql.Stmt
+	insertLoanword   *sql.Stmt
+}
+
+func prepareStmts(ctx context.Context, tx *sql.Tx) (*stmts, error) {
+	prep := func(q string, dst **sql.Stmt, failed *error) {
+		if *failed != nil {
+			return
+		}
+		st, err := tx.PrepareContext(ctx, q)
+		if err != nil {
+			*failed = err
+			return
+		}
+		*dst = st
+	}
+
+	var s stmts
+	var err error
+	prep(`INSERT INTO entries(sequence) VALUES (?)`, &s.insertEntry, &err)
+	prep(`INSERT INTO kanji_elements(entry_id, keb) VALUES (?, ?)`, &s.insertKanji, &err)
+	prep(`INSERT INTO kanji_fts(rowid, keb) VALUES (?, ?)`, &s.insertKanjiFTS, &err)
+	prep(`INSERT INTO reading_elements(entry_id, reb) VALUES (?, ?)`, &s.insertReading, &err)
+	prep(`INSERT INTO reading_fts(rowid, reb) VALUES (?, ?)`, &s.insertReadingFTS, &err)
+	prep(`INSERT INTO senses(entry_id, position) VALUES (?, ?)`, &s.insertSense, &err)
+	prep(`INSERT INTO glosses(sense_id, lang, value) VALUES (?, ?, ?)`, &s.insertGloss, &err)
+	prep(`INSERT INTO gloss_fts(rowid, value) VALUES (?, ?)`, &s.insertGlossFTS, &err)
+	prep(`INSERT INTO sense_pos(sense_id, code) VALUES (?, ?)`, &s.insertPOS, &err)
+	prep(`INSERT INTO sense_field(sense_id, code) VALUES (?, ?)`, &s.insertField, &err)
+	prep(`INSERT INTO sense_dialect(sense_id, code) VALUES (?, ?)`, &s.insertDialect, &err)
+	prep(`INSERT INTO sense_misc(sense_id, code) VALUES (?, ?)`, &s.insertMisc, &err)
+	prep(`INSERT INTO cross_refs(sense_id, target) VALUES (?, ?)`, &s.insertXRef, &err)
+	prep(`INSERT INTO antonyms(sense_id, target) VALUES (?, ?)`, &s.insertAntonym, &err)
+	prep(`INSERT INTO loanwords(sense_id, lang, ls_type, wasei, value) VALUES (?, ?, ?, ?, ?)`, &s.insertLoanword, &err)
+	if err != nil {
+		s.close()
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *stmts) close() {
+	for _, st := range []*sql.Stmt{
+		s.insertEntry, s.insertKanji, s.insertKanjiFTS, s.insertReading,
+		s.insertReadingFTS, s.insertSense, s.insertGloss, s.insertGlossFTS,
+		s.insertPOS, s.insertField, s.insertDialect,
+		s.insertMisc, s.insertXRef, s.insertAntonym, s.insertLoanword,
+	} {
+		if st != nil {
+			st.Close()
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+const sampleXML = `<?xml version="1.0" encoding="UTF-8"?>
+<JMdict>
+<entry>
+<ent_seq>1000</ent_seq>
+<k_ele><keb>日本語</keb></k_ele>
+<r_ele><reb>にほんご</reb></r_ele>
+<sense><pos>&n;</pos><gloss>Japanese language</gloss></sense>
+</entry>
+</JMdict>`
+
+func TestImportPopulatesFTS(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+
+	if err := Import(context.Background(), db, strings.NewReader(sampleXML), ImportOptions{}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	for _, tc := range []struct {
+		table, query string
+	}{
+		{"kanji_fts", "日本語"},
+		{"reading_fts", "にほんご"},
+		{"gloss_fts", "Japanese"},
+	} {
+		var count int
+		q := `SELECT count(*) FROM ` + tc.table + ` WHERE ` + tc.table + ` MATCH ?`
+		if err := db.QueryRow(q, tc.query).Scan(&count); err != nil {
+			t.Fatalf("query %s: %v", tc.table, err)
+		}
+		if count == 0 {
+			t.Errorf("%s MATCH %q: got 0 rows, want at least 1", tc.table, tc.query)
+		}
+	}
+}
+
+func TestImportStoresRawEntityCodes(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+
+	if err := Import(context.Background(), db, strings.NewReader(sampleXML), ImportOptions{}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	var code string
+	if err := db.QueryRow(`SELECT code FROM sense_pos`).Scan(&code); err != nil {
+		t.Fatalf("query sense_pos: %v", err)
+	}
+	if code != "n" {
+		t.Errorf("sense_pos.code = %q, want %q", code, "n")
+	}
+}
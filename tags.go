@@ -0,0 +1,310 @@
+package jmdict
+
+// Category classifies a JMdict entity code into one of the groupings used
+// by the JMdict DTD, so callers can filter entries by kind of tag instead
+// of matching against the raw entity map.
+type Category string
+
+// The tag categories mirror the element groupings in the JMdict DTD: part
+// of speech (pos), dialect (dial), domain/field (field), miscellaneous
+// usage (misc), and the kanji/reading qualifiers (ke_inf/re_inf).
+const (
+	CategoryPOS         Category = "pos"
+	CategoryDialect     Category = "dialect"
+	CategoryField       Category = "field"
+	CategoryMisc        Category = "misc"
+	CategoryKanjiInfo   Category = "kanji-info"
+	CategoryReadingInfo Category = "reading-info"
+)
+
+// TagInfo describes a single JMdict entity code: which category it
+// belongs to and its human-readable expansion. It marshals to JSON as the
+// raw code alongside its category and description, so callers don't need
+// a side lookup to render a human-readable label.
+type TagInfo struct {
+	Code        string   `json:"code"`
+	Category    Category `json:"category"`
+	Description string   `json:"description"`
+}
+
+// LookupTag returns the TagInfo for a JMdict entity code such as "v5k" or
+// "ksb". It reports false if code is not a known entity.
+func LookupTag(code string) (TagInfo, bool) {
+	desc, ok := entityDescription[code]
+	if !ok {
+		return TagInfo{}, false
+	}
+	cat, ok := tagCategory[code]
+	if !ok {
+		cat = CategoryMisc
+	}
+	return TagInfo{Code: code, Category: cat, Description: desc}, true
+}
+
+// tagCategory classifies every code in entityDescription into its JMdict
+// DTD grouping. Codes not listed here (there are none left unclassified
+// today, but additions to entityDescription default to CategoryMisc in
+// LookupTag) need not be added immediately.
+var tagCategory = map[string]Category{
+	// Part of speech (pos).
+	"adj-i": CategoryPOS, "adj-ix": CategoryPOS, "adj-na": CategoryPOS,
+	"adj-no": CategoryPOS, "adj-pn": CategoryPOS, "adj-t": CategoryPOS,
+	"adj-f": CategoryPOS, "adj-kari": CategoryPOS, "adj-ku": CategoryPOS,
+	"adj-shiku": CategoryPOS, "adj-nari": CategoryPOS,
+	"adv": CategoryPOS, "adv-to": CategoryPOS,
+	"aux": CategoryPOS, "aux-v": CategoryPOS, "aux-adj": CategoryPOS,
+	"conj": CategoryPOS, "cop-da": CategoryPOS, "ctr": CategoryPOS,
+	"exp": CategoryPOS, "int": CategoryPOS, "iv": CategoryPOS,
+	"n": CategoryPOS, "n-adv": CategoryPOS, "n-suf": CategoryPOS,
+	"n-pref": CategoryPOS, "n-t": CategoryPOS, "n-pr": CategoryPOS,
+	"num": CategoryPOS, "pn": CategoryPOS, "pref": CategoryPOS,
+	"prt": CategoryPOS, "suf": CategoryPOS, "v-unspec": CategoryPOS,
+	"v1": CategoryPOS, "v1-s": CategoryPOS, "vz": CategoryPOS,
+	"vi": CategoryPOS, "vk": CategoryPOS, "vn": CategoryPOS, "vr": CategoryPOS,
+	"vs": CategoryPOS, "vs-c": CategoryPOS, "vs-s": CategoryPOS, "vs-i": CategoryPOS,
+	"vt":    CategoryPOS,
+	"v5aru": CategoryPOS, "v5b": CategoryPOS, "v5g": CategoryPOS,
+	"v5k": CategoryPOS, "v5k-s": CategoryPOS, "v5m": CategoryPOS,
+	"v5n": CategoryPOS, "v5r": CategoryPOS, "v5r-i": CategoryPOS,
+	"v5s": CategoryPOS, "v5t": CategoryPOS, "v5u": CategoryPOS,
+	"v5u-s": CategoryPOS, "v5uru": CategoryPOS,
+	// Historical yodan/nidan conjugations, kept classified but without
+	// their own named constants below since callers rarely reference them
+	// directly.
+	"v2a-s": CategoryPOS, "v4h": CategoryPOS, "v4r": CategoryPOS,
+	"v4k": CategoryPOS, "v4g": CategoryPOS, "v4s": CategoryPOS,
+	"v4t": CategoryPOS, "v4n": CategoryPOS, "v4b": CategoryPOS, "v4m": CategoryPOS,
+	"v2k-k": CategoryPOS, "v2g-k": CategoryPOS, "v2t-k": CategoryPOS,
+	"v2d-k": CategoryPOS, "v2h-k": CategoryPOS, "v2b-k": CategoryPOS,
+	"v2m-k": CategoryPOS, "v2y-k": CategoryPOS, "v2r-k": CategoryPOS,
+	"v2k-s": CategoryPOS, "v2g-s": CategoryPOS, "v2s-s": CategoryPOS,
+	"v2z-s": CategoryPOS, "v2t-s": CategoryPOS, "v2d-s": CategoryPOS,
+	"v2n-s": CategoryPOS, "v2h-s": CategoryPOS, "v2b-s": CategoryPOS,
+	"v2m-s": CategoryPOS, "v2y-s": CategoryPOS, "v2r-s": CategoryPOS, "v2w-s": CategoryPOS,
+
+	// Dialect (dial).
+	"kyb": CategoryDialect, "osb": CategoryDialect, "ksb": CategoryDialect,
+	"ktb": CategoryDialect, "tsb": CategoryDialect, "thb": CategoryDialect,
+	"tsug": CategoryDialect, "kyu": CategoryDialect, "rkb": CategoryDialect,
+	"nab": CategoryDialect, "hob": CategoryDialect,
+
+	// Domain/field (field).
+	"MA": CategoryField, "Buddh": CategoryField, "chem": CategoryField,
+	"comp": CategoryField, "food": CategoryField, "geom": CategoryField,
+	"ling": CategoryField, "math": CategoryField, "mil": CategoryField,
+	"physics": CategoryField, "archit": CategoryField, "astron": CategoryField,
+	"baseb": CategoryField, "biol": CategoryField, "bot": CategoryField,
+	"bus": CategoryField, "econ": CategoryField, "engr": CategoryField,
+	"finc": CategoryField, "geol": CategoryField, "law": CategoryField,
+	"mahj": CategoryField, "med": CategoryField, "music": CategoryField,
+	"Shinto": CategoryField, "shogi": CategoryField, "sports": CategoryField,
+	"sumo": CategoryField, "zool": CategoryField, "anat": CategoryField,
+
+	// Kanji-form info (ke_inf).
+	"ateji": CategoryKanjiInfo, "iK": CategoryKanjiInfo,
+	"io": CategoryKanjiInfo, "oK": CategoryKanjiInfo, "eK": CategoryKanjiInfo,
+
+	// Reading info (re_inf).
+	"gikun": CategoryReadingInfo, "ik": CategoryReadingInfo,
+	"ok": CategoryReadingInfo, "oik": CategoryReadingInfo, "ek": CategoryReadingInfo,
+
+	// Miscellaneous usage (misc); everything else in entityDescription
+	// defaults here via LookupTag, these are simply made explicit.
+	"X": CategoryMisc, "abbr": CategoryMisc, "arch": CategoryMisc,
+	"chn": CategoryMisc, "col": CategoryMisc, "derog": CategoryMisc,
+	"fam": CategoryMisc, "fem": CategoryMisc, "hon": CategoryMisc,
+	"hum": CategoryMisc, "id": CategoryMisc, "joc": CategoryMisc,
+	"m-sl": CategoryMisc, "male": CategoryMisc, "male-sl": CategoryMisc,
+	"obs": CategoryMisc, "obsc": CategoryMisc, "on-mim": CategoryMisc,
+	"poet": CategoryMisc, "pol": CategoryMisc, "proverb": CategoryMisc,
+	"rare": CategoryMisc, "sens": CategoryMisc, "sl": CategoryMisc,
+	"uK": CategoryMisc, "uk": CategoryMisc, "vulg": CategoryMisc,
+	"yoji": CategoryMisc, "unc": CategoryMisc, "quote": CategoryMisc,
+}
+
+// POS classifies a part-of-speech entity code, e.g. POSGodanKu or
+// POSSuruVerb.
+type POS string
+
+// Part of speech.
+const (
+	POSAdjectiveI          POS = "adj-i"
+	POSAdjectiveIX         POS = "adj-ix"
+	POSAdjectivalNoun      POS = "adj-na"
+	POSAdjectiveNo         POS = "adj-no"
+	POSPreNounAdjectival   POS = "adj-pn"
+	POSAdjectiveTaru       POS = "adj-t"
+	POSAdjectivePrenominal POS = "adj-f"
+	POSAdjectiveKari       POS = "adj-kari"
+	POSAdjectiveKu         POS = "adj-ku"
+	POSAdjectiveShiku      POS = "adj-shiku"
+	POSAdjectiveNari       POS = "adj-nari"
+	POSAdverb              POS = "adv"
+	POSAdverbTo            POS = "adv-to"
+	POSAuxiliary           POS = "aux"
+	POSAuxiliaryVerb       POS = "aux-v"
+	POSAuxiliaryAdjective  POS = "aux-adj"
+	POSConjunction         POS = "conj"
+	POSCopula              POS = "cop-da"
+	POSCounter             POS = "ctr"
+	POSExpression          POS = "exp"
+	POSInterjection        POS = "int"
+	POSIrregularVerb       POS = "iv"
+	POSNoun                POS = "n"
+	POSAdverbialNoun       POS = "n-adv"
+	POSNounSuffix          POS = "n-suf"
+	POSNounPrefix          POS = "n-pref"
+	POSTemporalNoun        POS = "n-t"
+	POSProperNoun          POS = "n-pr"
+	POSNumeric             POS = "num"
+	POSPronoun             POS = "pn"
+	POSPrefix              POS = "pref"
+	POSParticle            POS = "prt"
+	POSSuffix              POS = "suf"
+	POSVerbUnspecified     POS = "v-unspec"
+	POSIchidan             POS = "v1"
+	POSIchidanKureru       POS = "v1-s"
+	POSIchidanZuru         POS = "vz"
+	POSIntransitive        POS = "vi"
+	POSKuruVerb            POS = "vk"
+	POSIrregularNuVerb     POS = "vn"
+	POSIrregularRiVerb     POS = "vr"
+	POSSuruVerb            POS = "vs"
+	POSSuVerb              POS = "vs-c"
+	POSSuruVerbSpecial     POS = "vs-s"
+	POSSuruVerbIrregular   POS = "vs-i"
+	POSTransitive          POS = "vt"
+	POSGodanAru            POS = "v5aru"
+	POSGodanBu             POS = "v5b"
+	POSGodanGu             POS = "v5g"
+	POSGodanKu             POS = "v5k"
+	POSGodanIkuYuku        POS = "v5k-s"
+	POSGodanMu             POS = "v5m"
+	POSGodanNu             POS = "v5n"
+	POSGodanRu             POS = "v5r"
+	POSGodanRuIrregular    POS = "v5r-i"
+	POSGodanSu             POS = "v5s"
+	POSGodanTsu            POS = "v5t"
+	POSGodanU              POS = "v5u"
+	POSGodanUSpecial       POS = "v5u-s"
+	POSGodanUru            POS = "v5uru"
+)
+
+// Dialect classifies a dialect entity code, e.g. DialectKansai.
+type Dialect string
+
+// Dialect.
+const (
+	DialectKyoto    Dialect = "kyb"
+	DialectOsaka    Dialect = "osb"
+	DialectKansai   Dialect = "ksb"
+	DialectKantou   Dialect = "ktb"
+	DialectTosa     Dialect = "tsb"
+	DialectTouhoku  Dialect = "thb"
+	DialectTsugaru  Dialect = "tsug"
+	DialectKyuushuu Dialect = "kyu"
+	DialectRyuukyuu Dialect = "rkb"
+	DialectNagano   Dialect = "nab"
+	DialectHokkaido Dialect = "hob"
+)
+
+// Field classifies a domain/field entity code, e.g. FieldComputing.
+type Field string
+
+// Domain/field.
+const (
+	FieldMartialArts  Field = "MA"
+	FieldBuddhism     Field = "Buddh"
+	FieldChemistry    Field = "chem"
+	FieldComputing    Field = "comp"
+	FieldFood         Field = "food"
+	FieldGeometry     Field = "geom"
+	FieldLinguistics  Field = "ling"
+	FieldMathematics  Field = "math"
+	FieldMilitary     Field = "mil"
+	FieldPhysics      Field = "physics"
+	FieldArchitecture Field = "archit"
+	FieldAstronomy    Field = "astron"
+	FieldBaseball     Field = "baseb"
+	FieldBiology      Field = "biol"
+	FieldBotany       Field = "bot"
+	FieldBusiness     Field = "bus"
+	FieldEconomics    Field = "econ"
+	FieldEngineering  Field = "engr"
+	FieldFinance      Field = "finc"
+	FieldGeology      Field = "geol"
+	FieldLaw          Field = "law"
+	FieldMahjong      Field = "mahj"
+	FieldMedicine     Field = "med"
+	FieldMusic        Field = "music"
+	FieldShinto       Field = "Shinto"
+	FieldShogi        Field = "shogi"
+	FieldSports       Field = "sports"
+	FieldSumo         Field = "sumo"
+	FieldZoology      Field = "zool"
+	FieldAnatomy      Field = "anat"
+)
+
+// KanjiInfo classifies a kanji-form qualifier entity code (ke_inf), e.g.
+// KanjiInfoAteji.
+type KanjiInfo string
+
+// Kanji-form info.
+const (
+	KanjiInfoAteji              KanjiInfo = "ateji"
+	KanjiInfoIrregularKanji     KanjiInfo = "iK"
+	KanjiInfoIrregularOkurigana KanjiInfo = "io"
+	KanjiInfoOutdatedKanji      KanjiInfo = "oK"
+	KanjiInfoExclusivelyKanji   KanjiInfo = "eK"
+)
+
+// ReadingInfo classifies a reading qualifier entity code (re_inf), e.g.
+// ReadingInfoGikun.
+type ReadingInfo string
+
+// Reading info.
+const (
+	ReadingInfoGikun            ReadingInfo = "gikun"
+	ReadingInfoIrregularKana    ReadingInfo = "ik"
+	ReadingInfoOutdatedKana     ReadingInfo = "ok"
+	ReadingInfoOldIrregularKana ReadingInfo = "oik"
+	ReadingInfoExclusivelyKana  ReadingInfo = "ek"
+)
+
+// Misc classifies a miscellaneous-usage entity code (misc), e.g.
+// MiscArchaism.
+type Misc string
+
+// Miscellaneous usage.
+const (
+	MiscRude          Misc = "X"
+	MiscAbbreviation  Misc = "abbr"
+	MiscArchaism      Misc = "arch"
+	MiscChildrensLang Misc = "chn"
+	MiscColloquialism Misc = "col"
+	MiscDerogatory    Misc = "derog"
+	MiscFamiliar      Misc = "fam"
+	MiscFeminine      Misc = "fem"
+	MiscHonorific     Misc = "hon"
+	MiscHumble        Misc = "hum"
+	MiscIdiomatic     Misc = "id"
+	MiscJocular       Misc = "joc"
+	MiscMangaSlang    Misc = "m-sl"
+	MiscMasculine     Misc = "male"
+	MiscMaleSlang     Misc = "male-sl"
+	MiscObsolete      Misc = "obs"
+	MiscObscure       Misc = "obsc"
+	MiscOnomatopoeic  Misc = "on-mim"
+	MiscPoetical      Misc = "poet"
+	MiscPolite        Misc = "pol"
+	MiscProverb       Misc = "proverb"
+	MiscRare          Misc = "rare"
+	MiscSensitive     Misc = "sens"
+	MiscSlang         Misc = "sl"
+	MiscUsuallyKanji  Misc = "uK"
+	MiscUsuallyKana   Misc = "uk"
+	MiscVulgar        Misc = "vulg"
+	MiscYojijukugo    Misc = "yoji"
+	MiscUnclassified  Misc = "unc"
+	MiscQuotation     Misc = "quote"
+)
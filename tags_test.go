@@ -0,0 +1,33 @@
+package jmdict
+
+import "testing"
+
+func TestLookupTag(t *testing.T) {
+	for _, tc := range []struct {
+		code     string
+		wantCat  Category
+		wantDesc string
+	}{
+		{"v5k", CategoryPOS, "godan verb"},
+		{"arch", CategoryMisc, "archaism"},
+		{"ksb", CategoryDialect, "kansai-ben"},
+	} {
+		info, ok := LookupTag(tc.code)
+		if !ok {
+			t.Errorf("LookupTag(%q): not found", tc.code)
+			continue
+		}
+		if info.Category != tc.wantCat {
+			t.Errorf("LookupTag(%q).Category = %q, want %q", tc.code, info.Category, tc.wantCat)
+		}
+		if info.Description != tc.wantDesc {
+			t.Errorf("LookupTag(%q).Description = %q, want %q", tc.code, info.Description, tc.wantDesc)
+		}
+	}
+}
+
+func TestLookupTagUnknownCode(t *testing.T) {
+	if _, ok := LookupTag("not-a-real-code"); ok {
+		t.Errorf("LookupTag(%q) reported ok=true, want false", "not-a-real-code")
+	}
+}